@@ -0,0 +1,104 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/lint"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var lintDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Validate Dolt configuration files against their JSON Schemas",
+	LongDesc: `dolt lint walks a directory (or validates a single file) and checks every
+dolt.yaml, replication config, remote config, and schema-import file it recognizes against the
+JSON Schema shipped for that file type, so bad configuration is caught before it reaches a repo.
+
+It prints every file it inspects, reports each violation with a file:line and a JSON pointer to
+the offending field, and exits non-zero if any file failed validation, making it usable as a CI
+gate.`,
+	Synopsis: []string{"[{{.LessThan}}path{{.GreaterThan}}]"},
+}
+
+// LintCmd implements the `dolt lint` command.
+type LintCmd struct{}
+
+var _ cli.Command = LintCmd{}
+
+// Name implements cli.Command.
+func (cmd LintCmd) Name() string {
+	return "lint"
+}
+
+// Description implements cli.Command.
+func (cmd LintCmd) Description() string {
+	return lintDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command. Lint can validate config files outside of a Dolt repo
+// (e.g. a remote config a user is staging before `dolt clone`), so it does not require one.
+func (cmd LintCmd) RequiresRepo() bool {
+	return false
+}
+
+// Docs implements cli.Command.
+func (cmd LintCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(lintDocs, cmd.ArgParser())
+}
+
+// ArgParser implements cli.Command.
+func (cmd LintCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"path", "The file or directory to lint. Defaults to the current directory."})
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd LintCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, lintDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	path := "."
+	if apr.NArg() > 0 {
+		path = apr.Arg(0)
+	}
+
+	inspected, violations, err := lint.Path(dEnv.FS, path)
+	if err != nil {
+		cli.PrintErrln(err)
+		usage()
+		return 1
+	}
+
+	for _, file := range inspected {
+		cli.Println(file)
+	}
+
+	for _, v := range violations {
+		cli.Println(v.String())
+	}
+
+	if len(violations) > 0 {
+		cli.Printf("%d violation(s) found in %d file(s)\n", len(violations), len(inspected))
+		return 1
+	}
+
+	cli.Printf("%d file(s) OK\n", len(inspected))
+	return 0
+}
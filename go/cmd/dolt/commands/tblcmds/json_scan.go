@@ -0,0 +1,175 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tblcmds holds the `dolt table` subcommands.
+package tblcmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const fileTypeParam = "file-type"
+
+var jsonScanDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Reports the schema Dolt would infer for a file and validates every row against it",
+	LongDesc: `dolt table scan reads {{.LessThan}}file{{.GreaterThan}} in two passes: the first infers a
+schema from the data, the second streams every row through that schema and reports how many rows
+it read, stopping with an error at the first row that fails. It does not write anything into the
+working set and is a separate command from `+"`dolt table import`"+`; it is useful for checking a file
+before importing it.
+
+The --file-type flag selects the reader used for the input file. If omitted, it is inferred from
+the file's extension. Currently only json is supported.`,
+	Synopsis: []string{"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}} [--file-type {{.LessThan}}type{{.GreaterThan}}]"},
+}
+
+// JSONScanCmd implements `dolt table scan`, a read-only preview of the schema and rows a
+// --file-type json import would produce. It is a distinct command from dolt's existing
+// multi-format `dolt table import` (CSV/PSV/XLSX) -- it does not replace or extend that command's
+// ImportCmd, and does not write into the working set the way import does.
+type JSONScanCmd struct{}
+
+var _ cli.Command = JSONScanCmd{}
+
+// Name implements cli.Command.
+func (cmd JSONScanCmd) Name() string {
+	return "scan"
+}
+
+// Description implements cli.Command.
+func (cmd JSONScanCmd) Description() string {
+	return jsonScanDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command.
+func (cmd JSONScanCmd) RequiresRepo() bool {
+	return true
+}
+
+// Docs implements cli.Command.
+func (cmd JSONScanCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(jsonScanDocs, cmd.ArgParser())
+}
+
+// ArgParser implements cli.Command.
+func (cmd JSONScanCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp,
+		[2]string{"table", "The table name schema inference should generate tags against."},
+		[2]string{"file", "The file to scan."},
+	)
+	ap.SupportsString(fileTypeParam, "", "type", "The type of file being scanned. Inferred from the file extension if omitted.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd JSONScanCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, jsonScanDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 2 {
+		usage()
+		return 1
+	}
+
+	tableName := apr.Arg(0)
+	path := apr.Arg(1)
+
+	fileType, ok := apr.GetValue(fileTypeParam)
+	if !ok {
+		fileType = fileTypeFromExt(path)
+	}
+
+	root, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		cli.PrintErrln(err)
+		return 1
+	}
+
+	rowsScanned, sch, err := scanTable(ctx, root, dEnv.DoltDB.Format(), dEnv.FS, tableName, fileType, path)
+	if err != nil {
+		cli.PrintErrln(err)
+		return 1
+	}
+
+	cli.Printf("%s: %d row(s) match the inferred %d-column schema (nothing written to the working set)\n", path, rowsScanned, sch.GetAllCols().Size())
+	return 0
+}
+
+// fileTypeFromExt guesses a --file-type value from path's extension.
+func fileTypeFromExt(path string) string {
+	return strings.TrimPrefix(filepath.Ext(path), ".")
+}
+
+// scanTable dispatches to the reader for fileType, infers a schema for path, and reads every row
+// out of it to confirm it validates against that schema. It returns the row count and the
+// inferred schema; it does not write into root.
+func scanTable(ctx context.Context, root *doltdb.RootValue, nbf *types.NomsBinFormat, fs filesys.ReadableFS, tableName, fileType, path string) (int, schema.Schema, error) {
+	switch fileType {
+	case "json":
+		return scanJSONTable(ctx, root, nbf, fs, tableName, path)
+	default:
+		return 0, nil, fmt.Errorf("unsupported file type %q", fileType)
+	}
+}
+
+func scanJSONTable(ctx context.Context, root *doltdb.RootValue, nbf *types.NomsBinFormat, fs filesys.ReadableFS, tableName, path string) (int, schema.Schema, error) {
+	info := &json.JSONFileInfo{}
+
+	sch, err := json.InferSchema(ctx, root, tableName, fs, path, info)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count, err := scanJSONRows(nbf, fs, sch, path)
+	return count, sch, err
+}
+
+// scanJSONRows opens a JSON file against the already-known schema sch and reads every row out of
+// it, returning the row count. It's split out from scanJSONTable so the second pass (the part
+// that doesn't need a *doltdb.RootValue) can be exercised directly, e.g. in tests that supply an
+// explicit schema instead of inferring one.
+func scanJSONRows(nbf *types.NomsBinFormat, fs filesys.ReadableFS, sch schema.Schema, path string) (int, error) {
+	rd, err := json.OpenJSONReader(nbf, path, fs, &json.JSONFileInfo{Sch: sch})
+	if err != nil {
+		return 0, err
+	}
+	defer rd.Close()
+
+	count := 0
+	for {
+		if _, err := rd.ReadRow(); err == io.EOF {
+			break
+		} else if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tblcmds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// TestScanJSONRows exercises the --file-type json scan path against an explicit schema, bypassing
+// the doltdb.RootValue-dependent schema inference pass.
+func TestScanJSONRows(t *testing.T) {
+	const path = "/data.json"
+	fs := filesys.NewInMemFS(nil, map[string][]byte{
+		path: []byte(`{"rows": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`),
+	}, "/")
+
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+	)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	count, err := scanJSONRows(types.Format_Default, fs, sch, path)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestFileTypeFromExt(t *testing.T) {
+	require.Equal(t, "json", fileTypeFromExt("foo/bar.json"))
+	require.Equal(t, "", fileTypeFromExt("foo/bar"))
+}
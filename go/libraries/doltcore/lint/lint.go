@@ -0,0 +1,216 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/jsonschema"
+)
+
+// targetSchemas maps a recognized config file name to the schema it should be validated
+// against. Schema-import files (consumed by sqlutil.ToDoltSchema) are matched by location
+// instead -- any .json file in a directory named "import" -- see schemaNameFor.
+var targetSchemas = map[string]string{
+	"dolt.yaml":        "dolt_yaml",
+	"replication.yaml": "replication",
+	"replication.json": "replication",
+	"remote.yaml":      "remote",
+	"remote.json":      "remote",
+}
+
+// Violation is a single JSON Schema failure found in a config file.
+type Violation struct {
+	File    string
+	Line    int
+	Pointer string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", v.File, v.Line, v.Pointer, v.Message)
+}
+
+// Path walks root (a single file or a directory) and validates every recognized config file it
+// finds against its JSON Schema. inspected lists every file that was checked, in the order they
+// were visited, regardless of whether it had violations.
+func Path(fs filesys.ReadableFS, root string) (inspected []string, violations []Violation, err error) {
+	isDir, err := fs.IsDir(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isDir {
+		v, ok, lintErr := lintFile(fs, root)
+		if lintErr != nil {
+			return nil, nil, lintErr
+		}
+		if ok {
+			inspected = append(inspected, root)
+			violations = append(violations, v...)
+		}
+		return inspected, violations, nil
+	}
+
+	err = fs.Iter(root, true, func(path string, size int64, isDir bool) (stop bool) {
+		if isDir {
+			return false
+		}
+
+		v, ok, lintErr := lintFile(fs, path)
+		if lintErr != nil {
+			err = lintErr
+			return true
+		}
+		if ok {
+			inspected = append(inspected, path)
+			violations = append(violations, v...)
+		}
+
+		return false
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return inspected, violations, nil
+}
+
+// lintFile validates path against its schema, if it's a file lint recognizes. ok is false for
+// files lint has no schema for -- those are not an error, just skipped.
+func lintFile(fs filesys.ReadableFS, path string) (violations []Violation, ok bool, err error) {
+	name, ok := schemaNameFor(path)
+	if !ok {
+		return nil, false, nil
+	}
+
+	schemaBytes, ok := defaultRegistry.get(name)
+	if !ok {
+		return nil, false, nil
+	}
+
+	schema, err := jsonschema.ParseJSONSchema(schemaBytes)
+	if err != nil {
+		return nil, false, fmt.Errorf("loading schema %q: %w", name, err)
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	doc, err := decodeConfig(path, data)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for _, verr := range schema.Validate(doc) {
+		violations = append(violations, Violation{
+			File:    path,
+			Line:    lineOf(data, verr.Pointer),
+			Pointer: verr.Pointer,
+			Message: verr.Message,
+		})
+	}
+
+	return violations, true, nil
+}
+
+// schemaNameFor returns the registered schema name for path, and whether lint recognizes it at
+// all.
+func schemaNameFor(path string) (string, bool) {
+	base := filepath.Base(path)
+	if name, ok := targetSchemas[base]; ok {
+		return name, true
+	}
+
+	if filepath.Base(filepath.Dir(path)) == "import" && strings.HasSuffix(base, ".json") {
+		return "schema_import", true
+	}
+
+	return "", false
+}
+
+// decodeConfig parses a config file into the plain map[string]interface{} form JSONSchema
+// validates, dispatching on extension between YAML and JSON.
+func decodeConfig(path string, data []byte) (map[string]interface{}, error) {
+	if strings.HasSuffix(path, ".json") {
+		var doc map[string]interface{}
+		err := json.Unmarshal(data, &doc)
+		return doc, err
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	doc, ok := normalizeYAML(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a YAML mapping at the document root")
+	}
+
+	return doc, nil
+}
+
+// normalizeYAML recursively converts the map[interface{}]interface{} values yaml.v2 produces
+// into map[string]interface{}, so the result can be validated by the same JSONSchema machinery
+// used for JSON documents.
+func normalizeYAML(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprintf("%v", key)] = normalizeYAML(value)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, item := range v {
+			s[i] = normalizeYAML(item)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// lineOf makes a best-effort guess at which line a JSON pointer's field appears on, by finding
+// the last path segment's key in the raw file contents. It returns 1 if the key can't be found.
+func lineOf(data []byte, pointer string) int {
+	segments := strings.Split(pointer, "/")
+	key := segments[len(segments)-1]
+	if key == "" {
+		return 1
+	}
+
+	idx := bytes.Index(data, []byte(`"`+key+`"`))
+	if idx < 0 {
+		idx = bytes.Index(data, []byte(key+":"))
+	}
+	if idx < 0 {
+		return 1
+	}
+
+	return bytes.Count(data[:idx], []byte("\n")) + 1
+}
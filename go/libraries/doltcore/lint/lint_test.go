@@ -0,0 +1,46 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// TestPathAcceptsIntegerPort is a regression test: yaml.v2 decodes `port: 3306` as a Go int, and
+// matchesType used to only accept float64 for the "integer"/"number" JSON Schema types, so a
+// valid dolt.yaml with a listener port was flagged with a spurious violation.
+func TestPathAcceptsIntegerPort(t *testing.T) {
+	const doltYaml = `
+log_level: info
+user:
+  name: bats
+  email: bats@dolthub.com
+listener:
+  host: 0.0.0.0
+  port: 3306
+`
+	fs := filesys.NewInMemFS(nil, map[string][]byte{
+		"/dolt.yaml": []byte(doltYaml),
+	}, "/")
+
+	inspected, violations, err := Path(fs, "/dolt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/dolt.yaml"}, inspected)
+	require.Empty(t, violations)
+}
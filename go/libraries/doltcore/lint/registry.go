@@ -0,0 +1,75 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint validates Dolt's YAML/JSON configuration artifacts against JSON Schemas shipped
+// inside the binary. It backs the `dolt lint` command; RegisterSchema lets other packages
+// replace the schema used for a recognized file type without modifying the lint command.
+package lint
+
+import (
+	"embed"
+	"path/filepath"
+	"sync"
+)
+
+//go:embed schemas/*.json
+var embeddedSchemas embed.FS
+
+// registry maps a schema name (e.g. "dolt_yaml", from schemas/dolt_yaml.schema.json) to the raw
+// JSON Schema bytes registered under it.
+type registry struct {
+	mu      sync.RWMutex
+	schemas map[string][]byte
+}
+
+var defaultRegistry = loadEmbeddedSchemas()
+
+func loadEmbeddedSchemas() *registry {
+	r := &registry{schemas: make(map[string][]byte)}
+
+	entries, err := embeddedSchemas.ReadDir("schemas")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, entry := range entries {
+		data, err := embeddedSchemas.ReadFile(filepath.Join("schemas", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		name := entry.Name()
+		name = name[:len(name)-len(".schema.json")]
+		r.schemas[name] = data
+	}
+
+	return r
+}
+
+// RegisterSchema registers a JSON Schema under name. Registering under an existing name
+// replaces it. `dolt lint` only looks up the names schemaNameFor returns (dolt_yaml,
+// replication, remote and schema_import); a schema registered under any other name is stored
+// but no file is validated against it.
+func RegisterSchema(name string, schema []byte) {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	defaultRegistry.schemas[name] = schema
+}
+
+func (r *registry) get(name string) ([]byte, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	data, ok := r.schemas[name]
+	return data, ok
+}
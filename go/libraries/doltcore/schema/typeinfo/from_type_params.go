@@ -0,0 +1,27 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+// jsonTypeInfoFromParams rebuilds a JSON TypeInfo from the params map GetTypeParams() produced
+// for it -- the inverse of jsonType.GetTypeParams. The package's central FromTypeParams dispatch
+// has cases for the other Identifiers and is not changed here: it has no JSONTypeIdentifier case
+// and does not call this function.
+func jsonTypeInfoFromParams(params map[string]string) (TypeInfo, error) {
+	schemaPath, ok := params["SchemaPath"]
+	if !ok {
+		return JSONType, nil
+	}
+	return JSONTypeWithSchema(schemaPath), nil
+}
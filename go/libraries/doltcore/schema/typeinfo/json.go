@@ -0,0 +1,240 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/jsonschema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// JSONTypeIdentifier is this TypeInfo's unique identifier, used to round-trip a column's
+// TypeInfo through its persisted schema metadata.
+const JSONTypeIdentifier = Identifier("json")
+
+// schemaCommentPrefix marks the JSON Schema a JSON column's values must satisfy, stashed in
+// schema.Column.Comment, e.g. `@schema=path/to/schema.json some other comment text`.
+const schemaCommentPrefix = "@schema="
+
+// JSONType is the default JSON TypeInfo: it stores arbitrary JSON documents with no per-column
+// validation. Use JSONTypeWithSchema for a column that should validate against a JSON Schema.
+var JSONType TypeInfo = &jsonType{}
+
+var _ TypeInfo = (*jsonType)(nil)
+
+// jsonType is the typeinfo.TypeInfo for JSON columns. It stores documents as a canonical
+// minified JSON string in Noms, and round-trips through go-mysql-server as jsonSqlType.
+type jsonType struct {
+	// schemaPath, if set, points at a JSON Schema document that object values written to this
+	// column must satisfy. It's loaded lazily (and cached) on first use.
+	schemaPath string
+
+	mu         sync.Mutex
+	loadedOnce bool
+	schema     *jsonschema.JSONSchema
+	loadErr    error
+}
+
+// JSONTypeWithSchema returns a JSON TypeInfo that validates each JSON object written to it
+// against the JSON Schema at schemaPath, using the format-checker registry in the jsonschema
+// package. Arrays and scalar values are stored without validation.
+func JSONTypeWithSchema(schemaPath string) TypeInfo {
+	return &jsonType{schemaPath: schemaPath}
+}
+
+// SchemaPathFromComment extracts the JSON Schema path from a column comment of the form
+// `@schema=path/to/schema.json`, if present.
+func SchemaPathFromComment(comment string) (string, bool) {
+	for _, field := range strings.Fields(comment) {
+		if strings.HasPrefix(field, schemaCommentPrefix) {
+			return strings.TrimPrefix(field, schemaCommentPrefix), true
+		}
+	}
+	return "", false
+}
+
+func (ti *jsonType) validationSchema() (*jsonschema.JSONSchema, error) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if ti.schemaPath == "" {
+		return nil, nil
+	}
+
+	if !ti.loadedOnce {
+		ti.schema, ti.loadErr = jsonschema.LoadJSONSchema(filesys.LocalFS, ti.schemaPath)
+		ti.loadedOnce = true
+	}
+
+	return ti.schema, ti.loadErr
+}
+
+// ConvertNomsValueToValue implements TypeInfo.
+func (ti *jsonType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+	if types.IsNull(v) {
+		return nil, nil
+	}
+
+	str, ok := v.(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%v is a %T, expected a types.String", v, v)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(str), &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// ConvertValueToNomsValue implements TypeInfo.
+func (ti *jsonType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+	if v == nil {
+		return types.NullValue, nil
+	}
+
+	doc, err := toJSONDoc(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema, err := ti.validationSchema(); err != nil {
+		return nil, err
+	} else if schema != nil {
+		if obj, ok := doc.(map[string]interface{}); ok {
+			if errs := schema.Validate(obj); len(errs) > 0 {
+				return nil, &jsonschema.RowValidationError{Errors: errs}
+			}
+		}
+	}
+
+	canonical, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.String(canonical), nil
+}
+
+// toJSONDoc normalizes v, which may already be a decoded document or may be its string/[]byte
+// serialization, into a decoded document ready for (re-)marshaling.
+func toJSONDoc(v interface{}) (interface{}, error) {
+	var raw []byte
+	switch val := v.(type) {
+	case string:
+		raw = []byte(val)
+	case []byte:
+		raw = val
+	default:
+		return val, nil
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Equals implements TypeInfo.
+func (ti *jsonType) Equals(other TypeInfo) bool {
+	o, ok := other.(*jsonType)
+	return ok && o.schemaPath == ti.schemaPath
+}
+
+// FormatValue implements TypeInfo.
+func (ti *jsonType) FormatValue(v types.Value) (*string, error) {
+	if types.IsNull(v) {
+		return nil, nil
+	}
+
+	str, ok := v.(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%v is a %T, expected a types.String", v, v)
+	}
+
+	s := string(str)
+	return &s, nil
+}
+
+// GetTypeIdentifier implements TypeInfo.
+func (ti *jsonType) GetTypeIdentifier() Identifier {
+	return JSONTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo.
+func (ti *jsonType) GetTypeParams() map[string]string {
+	if ti.schemaPath == "" {
+		return nil
+	}
+	return map[string]string{"SchemaPath": ti.schemaPath}
+}
+
+// IsValid implements TypeInfo.
+func (ti *jsonType) IsValid(v types.Value) bool {
+	if types.IsNull(v) {
+		return true
+	}
+
+	str, ok := v.(types.String)
+	if !ok {
+		return false
+	}
+
+	return json.Valid([]byte(str))
+}
+
+// NomsKind implements TypeInfo.
+func (ti *jsonType) NomsKind() types.NomsKind {
+	return types.StringKind
+}
+
+// ParseValue implements TypeInfo.
+func (ti *jsonType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil {
+		return types.NullValue, nil
+	}
+
+	nomsVal, err := ti.ConvertValueToNomsValue(*str)
+	if err != nil {
+		return nil, err
+	}
+
+	return nomsVal, nil
+}
+
+// Promote implements TypeInfo.
+func (ti *jsonType) Promote() TypeInfo {
+	return ti
+}
+
+// String implements TypeInfo.
+func (ti *jsonType) String() string {
+	return "Json"
+}
+
+// ToSqlType implements TypeInfo.
+func (ti *jsonType) ToSqlType() sql.Type {
+	return jsonSqlType{ti: ti}
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// jsonExtractExpr is the sql.Expression for `doc -> path` and `doc ->> path` against a JSON
+// column, evaluating through ExtractPath/ExtractPathUnquoted. NewJSONExtract and
+// NewJSONExtractUnquoted construct it; nothing in this package registers it with the SQL parser
+// or analyzer. Doc and Path evaluate to the document and the JSON path string respectively.
+type jsonExtractExpr struct {
+	Doc     sql.Expression
+	Path    sql.Expression
+	Unquote bool
+}
+
+var _ sql.Expression = (*jsonExtractExpr)(nil)
+
+// NewJSONExtract returns the expression for the `->` operator.
+func NewJSONExtract(doc, path sql.Expression) sql.Expression {
+	return &jsonExtractExpr{Doc: doc, Path: path}
+}
+
+// NewJSONExtractUnquoted returns the expression for the `->>` operator.
+func NewJSONExtractUnquoted(doc, path sql.Expression) sql.Expression {
+	return &jsonExtractExpr{Doc: doc, Path: path, Unquote: true}
+}
+
+// Resolved implements sql.Expression.
+func (e *jsonExtractExpr) Resolved() bool {
+	return e.Doc.Resolved() && e.Path.Resolved()
+}
+
+// String implements sql.Expression.
+func (e *jsonExtractExpr) String() string {
+	op := "->"
+	if e.Unquote {
+		op = "->>"
+	}
+	return fmt.Sprintf("(%s %s %s)", e.Doc, op, e.Path)
+}
+
+// Type implements sql.Expression. Both operators always produce a string (a quoted JSON
+// fragment for ->, an unquoted scalar for ->>).
+func (e *jsonExtractExpr) Type() sql.Type {
+	return StringDefaultType.ToSqlType()
+}
+
+// IsNullable implements sql.Expression.
+func (e *jsonExtractExpr) IsNullable() bool {
+	return true
+}
+
+// Children implements sql.Expression.
+func (e *jsonExtractExpr) Children() []sql.Expression {
+	return []sql.Expression{e.Doc, e.Path}
+}
+
+// WithChildren implements sql.Expression.
+func (e *jsonExtractExpr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, fmt.Errorf("%T: expected 2 children, got %d", e, len(children))
+	}
+	return &jsonExtractExpr{Doc: children[0], Path: children[1], Unquote: e.Unquote}, nil
+}
+
+// Eval implements sql.Expression, extracting e.Path out of e.Doc via ExtractPath or
+// ExtractPathUnquoted.
+func (e *jsonExtractExpr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	docVal, err := e.Doc.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if docVal == nil {
+		return nil, nil
+	}
+
+	pathVal, err := e.Path.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	path, ok := pathVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("JSON path must be a string, got %T", pathVal)
+	}
+
+	if e.Unquote {
+		return ExtractPathUnquoted(docVal, path)
+	}
+	return ExtractPath(docVal, path)
+}
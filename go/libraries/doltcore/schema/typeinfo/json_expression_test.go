@@ -0,0 +1,80 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONExtractExpr(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	doc := expression.NewLiteral(`{"a": {"b": [1, "two", 3]}}`, StringDefaultType.ToSqlType())
+
+	tests := []struct {
+		name     string
+		path     string
+		unquote  bool
+		expected interface{}
+	}{
+		{name: "string", path: "$.a.b[1]", unquote: false, expected: `"two"`},
+		{name: "string unquoted", path: "$.a.b[1]", unquote: true, expected: "two"},
+		{name: "number", path: "$.a.b[0]", unquote: false, expected: "1"},
+		{name: "number unquoted", path: "$.a.b[0]", unquote: true, expected: "1"},
+		{name: "missing path", path: "$.a.c", unquote: false, expected: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := expression.NewLiteral(test.path, StringDefaultType.ToSqlType())
+
+			var expr sql.Expression
+			if test.unquote {
+				expr = NewJSONExtractUnquoted(doc, path)
+			} else {
+				expr = NewJSONExtract(doc, path)
+			}
+
+			assert.True(t, expr.Resolved())
+			assert.Len(t, expr.Children(), 2)
+
+			got, err := expr.Eval(ctx, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestJSONExtractExprWithChildren(t *testing.T) {
+	doc := expression.NewLiteral(`{"a": 1}`, StringDefaultType.ToSqlType())
+	path := expression.NewLiteral("$.a", StringDefaultType.ToSqlType())
+	expr := NewJSONExtract(doc, path)
+
+	_, err := expr.WithChildren(doc)
+	assert.Error(t, err)
+
+	newDoc := expression.NewLiteral(`{"a": 2}`, StringDefaultType.ToSqlType())
+	newPath := expression.NewLiteral("$.a", StringDefaultType.ToSqlType())
+	rebuilt, err := expr.WithChildren(newDoc, newPath)
+	require.NoError(t, err)
+
+	got, err := rebuilt.Eval(sql.NewEmptyContext(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2", got)
+}
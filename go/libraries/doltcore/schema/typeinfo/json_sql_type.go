@@ -0,0 +1,246 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	querypb "github.com/dolthub/vitess/go/vt/proto/query"
+)
+
+// jsonSqlType is the sql.Type bridge for JSON columns, following go-mysql-server's built-in
+// JSON type contract so JSON columns behave like any other SQL column to the query engine,
+// while routing storage and validation through the owning jsonType.
+type jsonSqlType struct {
+	ti *jsonType
+}
+
+var _ sql.Type = jsonSqlType{}
+
+// Type implements sql.Type.
+func (t jsonSqlType) Type() querypb.Type {
+	return sqltypes.TypeJSON
+}
+
+// Zero implements sql.Type.
+func (t jsonSqlType) Zero() interface{} {
+	return "{}"
+}
+
+// String implements sql.Type.
+func (t jsonSqlType) String() string {
+	return "JSON"
+}
+
+// Promote implements sql.Type.
+func (t jsonSqlType) Promote() sql.Type {
+	return t
+}
+
+// Convert implements sql.Type, validating and canonicalizing v into a minified JSON string.
+func (t jsonSqlType) Convert(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	nomsVal, err := t.ti.ConvertValueToNomsValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	native, err := t.ti.ConvertNomsValueToValue(nomsVal)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical, err := json.Marshal(native)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(canonical), nil
+}
+
+// MustConvert implements sql.Type.
+func (t jsonSqlType) MustConvert(v interface{}) interface{} {
+	converted, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return converted
+}
+
+// Compare implements sql.Type by comparing the canonicalized string forms of two JSON
+// documents.
+func (t jsonSqlType) Compare(a, b interface{}) (int, error) {
+	if a == nil && b == nil {
+		return 0, nil
+	} else if a == nil {
+		return -1, nil
+	} else if b == nil {
+		return 1, nil
+	}
+
+	as, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return strings.Compare(as.(string), bs.(string)), nil
+}
+
+// SQL implements sql.Type.
+func (t jsonSqlType) SQL(dest []byte, v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+
+	converted, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, append(dest, converted.(string)...)), nil
+}
+
+// ExtractPath implements the `->` operator: the JSON value found at path, re-encoded as JSON
+// (so a string result stays quoted).
+func ExtractPath(doc interface{}, path string) (interface{}, error) {
+	val, err := navigate(doc, path)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// ExtractPathUnquoted implements the `->>` operator: like ExtractPath, but a string result is
+// returned unquoted.
+func ExtractPathUnquoted(doc interface{}, path string) (interface{}, error) {
+	val, err := navigate(doc, path)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+
+	encoded, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// navigate walks doc (a decoded JSON document, or its string/[]byte serialization) following a
+// MySQL-style JSON path such as "$.a.b[0]". It supports the subset of the spec dolt's -> and ->>
+// operators need: dotted object keys and bracketed array indexes.
+func navigate(doc interface{}, path string) (interface{}, error) {
+	d, err := toJSONDoc(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := d
+	for _, seg := range segments {
+		if cur == nil {
+			return nil, nil
+		}
+
+		if idx, isIndex := seg.index(); isIndex {
+			arr, ok := cur.([]interface{})
+			if !ok || idx < 0 || idx >= len(arr) {
+				return nil, nil
+			}
+			cur = arr[idx]
+			continue
+		}
+
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		cur = obj[seg.key]
+	}
+
+	return cur, nil
+}
+
+type pathSegment struct {
+	key string
+	idx int
+	arr bool
+}
+
+func (s pathSegment) index() (int, bool) {
+	return s.idx, s.arr
+}
+
+// splitPath parses a JSON path like "$.a.b[2].c" into its segments.
+func splitPath(path string) ([]pathSegment, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+
+	var segments []pathSegment
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", path)
+			}
+			segments = append(segments, pathSegment{key: path[:end]})
+			path = path[end:]
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid JSON path: unterminated '['")
+			}
+			idx, err := strconv.Atoi(path[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON path array index %q", path[1:end])
+			}
+			segments = append(segments, pathSegment{idx: idx, arr: true})
+			path = path[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q", path)
+		}
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONTypeFromTypeParamsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		ti   TypeInfo
+	}{
+		{name: "no schema", ti: JSONType},
+		{name: "with schema", ti: JSONTypeWithSchema("schemas/widget.json")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := test.ti.GetTypeIdentifier()
+			assert.Equal(t, JSONTypeIdentifier, id)
+
+			params := test.ti.GetTypeParams()
+
+			rebuilt, err := jsonTypeInfoFromParams(params)
+			require.NoError(t, err)
+			assert.True(t, test.ti.Equals(rebuilt))
+		})
+	}
+}
+
+func TestJSONTypeValueRoundTrip(t *testing.T) {
+	doc := map[string]interface{}{"a": float64(1), "b": "two"}
+
+	nomsVal, err := JSONType.ConvertValueToNomsValue(doc)
+	require.NoError(t, err)
+
+	got, err := JSONType.ConvertNomsValueToValue(nomsVal)
+	require.NoError(t, err)
+	assert.Equal(t, doc, got)
+}
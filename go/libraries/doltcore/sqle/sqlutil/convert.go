@@ -20,6 +20,7 @@ import (
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -129,7 +130,7 @@ func ToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableName string,
 	var kinds []types.NomsKind
 	for _, col := range sqlSchema {
 		names = append(names, col.Name)
-		ti, err := typeinfo.FromSqlType(col.Type)
+		ti, err := typeInfoForSqlColumn(col)
 		if err != nil {
 			return nil, err
 		}
@@ -171,10 +172,26 @@ func ToDoltCol(tag uint64, col *sql.Column) (schema.Column, error) {
 	if !col.Nullable {
 		constraints = append(constraints, schema.NotNullConstraint{})
 	}
-	typeInfo, err := typeinfo.FromSqlType(col.Type)
+	typeInfo, err := typeInfoForSqlColumn(col)
 	if err != nil {
 		return schema.Column{}, err
 	}
 
 	return schema.NewColumnWithTypeInfo(col.Name, tag, typeInfo, col.PrimaryKey, col.Default.String(), col.AutoIncrement, col.Comment, constraints...)
 }
+
+// typeInfoForSqlColumn is typeinfo.FromSqlType plus JSON support: FromSqlType doesn't know about
+// sql.Type implementations carrying the JSON query type, so this maps them to typeinfo.JSONType
+// itself. A column comment of the form `@schema=path/to/schema.json` attaches
+// per-column JSON Schema validation to the resulting TypeInfo.
+func typeInfoForSqlColumn(col *sql.Column) (typeinfo.TypeInfo, error) {
+	if col.Type.Type() != sqltypes.TypeJSON {
+		return typeinfo.FromSqlType(col.Type)
+	}
+
+	if schemaPath, ok := typeinfo.SchemaPathFromComment(col.Comment); ok {
+		return typeinfo.JSONTypeWithSchema(schemaPath), nil
+	}
+
+	return typeinfo.JSONType, nil
+}
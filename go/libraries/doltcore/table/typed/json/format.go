@@ -0,0 +1,30 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+// JSONFormat selects the on-disk framing used by JSONReader and JSONWriter.
+type JSONFormat int
+
+const (
+	// FormatArrayWrapped is the `{"rows": [...]}` framing Dolt has always written.
+	FormatArrayWrapped JSONFormat = iota
+
+	// FormatJSONLines writes one compact object per line, with no enclosing array and no
+	// commas between rows.
+	FormatJSONLines
+
+	// FormatPretty is FormatArrayWrapped with each row indented for human readability.
+	FormatPretty
+)
@@ -0,0 +1,148 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// kind is the narrowest JSON value classification seen for a column so far. promote combines
+// the kinds seen for a column: kindNull yields to any other kind, kindInt and kindFloat combine
+// to kindFloat, and any other pair of different kinds combines to kindString. kindDatetime only
+// applies to strings that parse as RFC 3339 timestamps; any other string is kindString.
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindDatetime
+	kindString
+)
+
+// InferSchema walks every row in the JSON file at path, inferring the narrowest compatible
+// typeinfo.TypeInfo for each key it encounters, and returns a Dolt schema for the result. It is
+// the first of two passes over the file: callers should follow up with OpenJSONReader to
+// actually stream the rows once a schema is known.
+func InferSchema(ctx context.Context, root *doltdb.RootValue, tableName string, fs filesys.ReadableFS, path string, info *JSONFileInfo) (schema.Schema, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	dec, err := newRowDecoder(bufio.NewReaderSize(r, ReadBufSize), info.Format == FormatJSONLines)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[string]kind)
+	var order []string
+
+	for {
+		obj, err := dec.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		for key, val := range obj {
+			k, ok := kinds[key]
+			if !ok {
+				order = append(order, key)
+			}
+			kinds[key] = promote(k, kindOf(val))
+		}
+	}
+
+	sqlSchema := make(sql.Schema, len(order))
+	for i, key := range order {
+		sqlSchema[i] = &sql.Column{
+			Name:     key,
+			Type:     typeInfoForKind(kinds[key]).ToSqlType(),
+			Nullable: true,
+		}
+	}
+
+	return sqlutil.ToDoltSchema(ctx, root, tableName, sqlSchema)
+}
+
+// kindOf classifies a single decoded JSON value.
+func kindOf(val interface{}) kind {
+	switch v := val.(type) {
+	case nil:
+		return kindNull
+	case bool:
+		return kindBool
+	case float64:
+		if v == float64(int64(v)) {
+			return kindInt
+		}
+		return kindFloat
+	case string:
+		if _, err := time.Parse(time.RFC3339, v); err == nil {
+			return kindDatetime
+		}
+		return kindString
+	default:
+		// objects, arrays, and anything else are stored as their string representation
+		return kindString
+	}
+}
+
+// promote returns the narrowest kind that is compatible with both a and b.
+func promote(a, b kind) kind {
+	if a == kindNull {
+		return b
+	}
+	if b == kindNull {
+		return a
+	}
+	if a == b {
+		return a
+	}
+	if (a == kindInt && b == kindFloat) || (a == kindFloat && b == kindInt) {
+		return kindFloat
+	}
+	return kindString
+}
+
+func typeInfoForKind(k kind) typeinfo.TypeInfo {
+	switch k {
+	case kindBool:
+		return typeinfo.BoolType
+	case kindInt:
+		return typeinfo.Int64Type
+	case kindFloat:
+		return typeinfo.Float64Type
+	case kindDatetime:
+		return typeinfo.DatetimeType
+	default:
+		return typeinfo.StringDefaultType
+	}
+}
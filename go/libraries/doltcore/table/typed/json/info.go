@@ -0,0 +1,49 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/jsonschema"
+)
+
+// JSONFileInfo describes how a JSON file should be read or written.
+type JSONFileInfo struct {
+	// Sch is the schema to use when reading or writing. It must be set on read: NewJSONReader
+	// returns an error when it is nil. InferSchema builds a schema from the data itself.
+	Sch schema.Schema
+
+	// Format selects the on-disk framing to read or write. Defaults to FormatArrayWrapped.
+	Format JSONFormat
+
+	// SchemaPath, when set, points at a JSON Schema document that every row must satisfy.
+	// Readers and writers load it on open and validate each row's decoded map against it.
+	SchemaPath string
+
+	// ValidationSchema is the parsed form of SchemaPath. Open* helpers populate it
+	// automatically; callers driving NewJSONReader/NewJSONWriter directly (e.g. over stdin)
+	// can set it themselves to skip the SchemaPath file lookup.
+	ValidationSchema *jsonschema.JSONSchema
+
+	// BadRowSink, when set, receives rows that fail schema validation instead of aborting the
+	// read or write with a RowValidationError.
+	BadRowSink jsonschema.BadRowSink
+}
+
+// NewJSONFileInfo returns a JSONFileInfo for the given schema. NewJSONReader rejects a
+// JSONFileInfo whose Sch is nil; InferSchema builds a schema from the data.
+func NewJSONFileInfo(sch schema.Schema) *JSONFileInfo {
+	return &JSONFileInfo{Sch: sch}
+}
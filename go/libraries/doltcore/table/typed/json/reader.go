@@ -0,0 +1,236 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/jsonschema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ReadBufSize = 256 * 1024
+
+// JSONReader reads rows out of a JSON file. It understands both the `{"rows": [...]}` format
+// Dolt writes today, and JSON Lines (NDJSON), one object per line. It implements the same
+// reader interface consumed by table.PipeRows, so it can be plugged in wherever a
+// table.TableReadCloser is expected.
+type JSONReader struct {
+	closer     io.Closer
+	dec        *rowDecoder
+	nbf        *types.NomsBinFormat
+	sch        schema.Schema
+	valSchema  *jsonschema.JSONSchema
+	badRowSink jsonschema.BadRowSink
+}
+
+// OpenJSONReader opens a JSON file for reading.
+func OpenJSONReader(nbf *types.NomsBinFormat, path string, fs filesys.ReadableFS, info *JSONFileInfo) (*JSONReader, error) {
+	r, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if info != nil && info.SchemaPath != "" && info.ValidationSchema == nil {
+		info.ValidationSchema, err = jsonschema.LoadJSONSchema(fs, info.SchemaPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewJSONReader(nbf, r, info)
+}
+
+// NewJSONReader returns a JSONReader that reads rows matching info.Sch out of r. info.Sch must
+// be populated; callers that need schema inference should run InferSchema over the data first.
+func NewJSONReader(nbf *types.NomsBinFormat, r io.ReadCloser, info *JSONFileInfo) (*JSONReader, error) {
+	if info == nil || info.Sch == nil {
+		return nil, errors.New("schema must be provided to read a JSON file")
+	}
+
+	dec, err := newRowDecoder(bufio.NewReaderSize(r, ReadBufSize), info.Format == FormatJSONLines)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONReader{r, dec, nbf, info.Sch, info.ValidationSchema, info.BadRowSink}, nil
+}
+
+// GetSchema implements table.TableReadCloser
+func (jsonr *JSONReader) GetSchema() schema.Schema {
+	return jsonr.sch
+}
+
+// ReadRow reads a row from the JSON file. Returns io.EOF when there are no more rows. Rows that
+// fail schema validation are either funneled to the configured BadRowSink (and reading
+// continues with the next row) or returned as a *RowValidationError.
+func (jsonr *JSONReader) ReadRow() (row.Row, error) {
+	for {
+		colValMap, err := jsonr.dec.next()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if jsonr.valSchema != nil {
+			if errs := jsonr.valSchema.Validate(colValMap); len(errs) > 0 {
+				if jsonr.badRowSink != nil {
+					if sinkErr := jsonr.badRowSink.WriteBadRow(colValMap, errs); sinkErr != nil {
+						return nil, sinkErr
+					}
+					continue
+				}
+
+				return nil, &jsonschema.RowValidationError{Errors: errs}
+			}
+		}
+
+		return rowFromValMap(jsonr.nbf, jsonr.sch, colValMap)
+	}
+}
+
+// Close releases resources held by the reader.
+func (jsonr *JSONReader) Close() error {
+	if jsonr.closer != nil {
+		err := jsonr.closer.Close()
+		jsonr.closer = nil
+		return err
+	}
+
+	return errors.New("already closed")
+}
+
+// rowFromValMap converts a decoded JSON object into a row.Row matching sch, converting each
+// value through the column's TypeInfo.
+func rowFromValMap(nbf *types.NomsBinFormat, sch schema.Schema, colValMap map[string]interface{}) (row.Row, error) {
+	taggedVals := make(row.TaggedValues)
+
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := colValMap[col.Name]
+		if !ok || val == nil {
+			return false, nil
+		}
+
+		nomsVal, err := col.TypeInfo.ConvertValueToNomsValue(val)
+		if err != nil {
+			return true, err
+		}
+
+		taggedVals[tag] = nomsVal
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return row.New(nbf, sch, taggedVals)
+}
+
+// rowDecoder streams successive JSON objects out of a reader, handling both the array-wrapped
+// `{"rows": [...]}` format and JSON Lines framing.
+type rowDecoder struct {
+	dec       *json.Decoder
+	jsonLines bool
+	entered   bool
+}
+
+func newRowDecoder(r io.Reader, jsonLines bool) (*rowDecoder, error) {
+	return &rowDecoder{dec: json.NewDecoder(r), jsonLines: jsonLines}, nil
+}
+
+// next decodes and returns the next row object in the stream. It returns io.EOF once the
+// stream is exhausted.
+func (d *rowDecoder) next() (map[string]interface{}, error) {
+	if d.jsonLines {
+		return d.decodeNext()
+	}
+
+	if !d.entered {
+		if err := d.enterRowsArray(); err != nil {
+			return nil, err
+		}
+		d.entered = true
+	}
+
+	if !d.dec.More() {
+		// consume the closing `]` and `}` tokens
+		if _, err := d.dec.Token(); err != nil {
+			return nil, io.EOF
+		}
+		if _, err := d.dec.Token(); err != nil {
+			return nil, io.EOF
+		}
+		return nil, io.EOF
+	}
+
+	return d.decodeNext()
+}
+
+func (d *rowDecoder) decodeNext() (map[string]interface{}, error) {
+	var obj map[string]interface{}
+	if err := d.dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// enterRowsArray consumes the leading `{ "rows": [` tokens of the wrapped format, leaving the
+// decoder positioned at the first element of the array (if any).
+func (d *rowDecoder) enterRowsArray() error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errors.New("malformed json: expected '{'")
+	}
+
+	for d.dec.More() {
+		tok, err = d.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, ok := tok.(string)
+		if !ok {
+			return errors.New("malformed json: expected object key")
+		}
+
+		if key != "rows" {
+			return errors.New("malformed json: expected \"rows\" key")
+		}
+
+		tok, err = d.dec.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return errors.New("malformed json: expected 'rows' array")
+		}
+
+		return nil
+	}
+
+	return errors.New("malformed json: missing \"rows\" key")
+}
@@ -1,28 +1,44 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package json
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
 	"path/filepath"
 
-	"github.com/attic-labs/noms/go/types"
-	"github.com/liquidata-inc/ld/dolt/go/cmd/dolt/cli"
-	"github.com/liquidata-inc/ld/dolt/go/libraries/doltcore/row"
-	"github.com/liquidata-inc/ld/dolt/go/libraries/doltcore/schema"
-	"github.com/liquidata-inc/ld/dolt/go/libraries/utils/filesys"
-	"github.com/liquidata-inc/ld/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/libraries/utils/jsonschema"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
-const jsonHeader = `{"rows": [`
-const jsonFooter = `]}`
-
 var WriteBufSize = 256 * 1024
 
+// JSONWriter writes rows to a JSON file in one of the framings described by JSONFormat. Rows are
+// streamed straight to the underlying buffered writer as they arrive -- the writer never holds
+// more than one row in memory, so exporting a multi-million row table doesn't balloon memory.
 type JSONWriter struct {
 	closer      io.Closer
 	bWr         *bufio.Writer
+	enc         *json.Encoder
 	info        *JSONFileInfo
 	sch         schema.Schema
 	rowsWritten int
@@ -41,17 +57,35 @@ func OpenJSONWriter(path string, fs filesys.WritableFS, outSch schema.Schema, in
 		return nil, err
 	}
 
+	if info != nil && info.SchemaPath != "" && info.ValidationSchema == nil {
+		info.ValidationSchema, err = jsonschema.LoadJSONSchema(fs, info.SchemaPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return NewJSONWriter(wr, outSch, info)
 }
 
 func NewJSONWriter(wr io.WriteCloser, outSch schema.Schema, info *JSONFileInfo) (*JSONWriter, error) {
-
 	bwr := bufio.NewWriterSize(wr, WriteBufSize)
-	err := iohelp.WriteAll(bwr, []byte(jsonHeader))
-	if err != nil {
-		return nil, err
+
+	if info == nil {
+		info = &JSONFileInfo{}
+	}
+
+	if info.Format != FormatJSONLines {
+		if err := iohelp.WriteAll(bwr, []byte(jsonHeader(info.Format))); err != nil {
+			return nil, err
+		}
 	}
-	return &JSONWriter{wr, bwr, info, outSch, 0}, nil
+
+	enc := json.NewEncoder(bwr)
+	if info.Format == FormatPretty {
+		enc.SetIndent("", "  ")
+	}
+
+	return &JSONWriter{wr, bwr, enc, info, outSch, 0}, nil
 }
 
 func (jsonw *JSONWriter) GetSchema() schema.Schema {
@@ -61,31 +95,28 @@ func (jsonw *JSONWriter) GetSchema() schema.Schema {
 // WriteRow will write a row to a table
 func (jsonw *JSONWriter) WriteRow(r row.Row) error {
 	allCols := jsonw.sch.GetAllCols()
-	colValMap := make(map[string]interface{}, allCols.Size())
-	allCols.Iter(func(tag uint64, col schema.Column) (stop bool) {
-		val, ok := r.GetColVal(tag)
-		if ok && !types.IsNull(val) {
-			colValMap[col.Name] = val
-		}
 
-		return false
-	})
+	if jsonw.info.ValidationSchema != nil {
+		nativeVals := nativeValMap(allCols, r)
+		if errs := jsonw.info.ValidationSchema.Validate(nativeVals); len(errs) > 0 {
+			if jsonw.info.BadRowSink != nil {
+				return jsonw.info.BadRowSink.WriteBadRow(nativeVals, errs)
+			}
 
-	data, err := marshalToJson(colValMap)
-	if err != nil {
-		return errors.New("marshaling did not work")
+			return &jsonschema.RowValidationError{Errors: errs}
+		}
 	}
 
-	cli.Println(string(data))
-
-	if jsonw.rowsWritten != 0 {
-		jsonw.bWr.WriteRune(',')
+	if jsonw.info.Format != FormatJSONLines && jsonw.rowsWritten != 0 {
+		if err := jsonw.bWr.WriteByte(','); err != nil {
+			return err
+		}
 	}
 
-	newErr := iohelp.WriteAll(jsonw.bWr, data)
-	if newErr != nil {
-		return newErr
+	if err := jsonw.enc.Encode(orderedRow{allCols, r}); err != nil {
+		return err
 	}
+
 	jsonw.rowsWritten++
 
 	return nil
@@ -94,10 +125,10 @@ func (jsonw *JSONWriter) WriteRow(r row.Row) error {
 // Close should flush all writes, release resources being held
 func (jsonw *JSONWriter) Close() error {
 	if jsonw.closer != nil {
-		err := iohelp.WriteAll(jsonw.bWr, []byte(jsonFooter))
-
-		if err != nil {
-			return err
+		if jsonw.info.Format != FormatJSONLines {
+			if err := iohelp.WriteAll(jsonw.bWr, []byte(jsonFooter)); err != nil {
+				return err
+			}
 		}
 
 		errFl := jsonw.bWr.Flush()
@@ -111,17 +142,88 @@ func (jsonw *JSONWriter) Close() error {
 		return errFl
 	}
 	return errors.New("already closed")
+}
 
+func jsonHeader(format JSONFormat) string {
+	if format == FormatPretty {
+		return "{\"rows\": [\n"
+	}
+	return `{"rows": [`
 }
 
-func marshalToJson(valMap interface{}) ([]byte, error) {
-	var jsonBytes []byte
-	var err error
+const jsonFooter = `]}`
 
-	jsonBytes, err = json.Marshal(valMap)
-	cli.Println(string(jsonBytes))
-	if err != nil {
-		return nil, err
-	}
-	return jsonBytes, nil
-}
\ No newline at end of file
+// orderedRow marshals a row.Row as a JSON object whose keys follow schema column order, since
+// Go's map iteration (and encoding/json's alphabetic re-sorting of map keys) would otherwise
+// scramble it -- order matters for diffable exports.
+type orderedRow struct {
+	cols *schema.ColCollection
+	r    row.Row
+}
+
+func (o orderedRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	first := true
+	var marshalErr error
+	o.cols.Iter(func(tag uint64, col schema.Column) (stop bool) {
+		val, ok := o.r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			return false
+		}
+
+		nativeVal, err := col.TypeInfo.ConvertNomsValueToValue(val)
+		if err != nil {
+			marshalErr = err
+			return true
+		}
+
+		keyBytes, err := json.Marshal(col.Name)
+		if err != nil {
+			marshalErr = err
+			return true
+		}
+
+		valBytes, err := json.Marshal(nativeVal)
+		if err != nil {
+			marshalErr = err
+			return true
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(valBytes)
+
+		return false
+	})
+
+	buf.WriteByte('}')
+	return buf.Bytes(), marshalErr
+}
+
+// nativeValMap converts a row's column values to plain Go values (rather than noms types.Value)
+// so they can be checked against a JSON Schema.
+func nativeValMap(allCols *schema.ColCollection, r row.Row) map[string]interface{} {
+	valMap := make(map[string]interface{}, allCols.Size())
+	allCols.Iter(func(tag uint64, col schema.Column) (stop bool) {
+		val, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			return false
+		}
+
+		nativeVal, err := col.TypeInfo.ConvertNomsValueToValue(val)
+		if err == nil {
+			valMap[col.Name] = nativeVal
+		}
+
+		return false
+	})
+
+	return valMap
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const benchRowCount = 1_000_000
+
+// discardWriteCloser adapts ioutil.Discard to io.WriteCloser so the benchmark can exercise the
+// full writer without touching disk.
+type discardWriteCloser struct{ io.Writer }
+
+func (discardWriteCloser) Close() error { return nil }
+
+func benchSchema() schema.Schema {
+	colColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.NewColumn("score", 2, types.FloatKind, false),
+	)
+	return schema.SchemaFromCols(colColl)
+}
+
+func benchRow(sch schema.Schema, i int) row.Row {
+	taggedVals := row.TaggedValues{
+		0: types.Int(i),
+		1: types.String(fmt.Sprintf("row-%d", i)),
+		2: types.Float(float64(i) / 3),
+	}
+	r, _ := row.New(types.Format_Default, sch, taggedVals)
+	return r
+}
+
+// BenchmarkJSONWriterWriteRow writes benchRowCount rows through a JSONWriter discarding the
+// output. Run with `go test -bench WriteRow -benchmem` and compare against the same benchmark run
+// against the parent commit to see the effect of a change to the writer's hot path on allocations
+// and throughput.
+func BenchmarkJSONWriterWriteRow(b *testing.B) {
+	sch := benchSchema()
+
+	rows := make([]row.Row, benchRowCount)
+	for j := range rows {
+		rows[j] = benchRow(sch, j)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		wr, err := NewJSONWriter(discardWriteCloser{ioutil.Discard}, sch, &JSONFileInfo{})
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, r := range rows {
+			if err := wr.WriteRow(r); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		if err := wr.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// FormatChecker validates that a decoded JSON value satisfies a named JSON Schema "format"
+// keyword, e.g. "date-time" or "uuid". Implementations should only be asked to check values
+// that already passed the "type" keyword they apply to (string, for all of the built-ins
+// below).
+type FormatChecker interface {
+	IsFormat(input interface{}) bool
+}
+
+// formatCheckerRegistry is a registry of named FormatCheckers, seeded with the built-ins below.
+// It is safe for concurrent use so that plugin code can register custom checkers (e.g. a
+// "dolt-tag" format) before a reader or writer is opened on another goroutine.
+type formatCheckerRegistry struct {
+	mu       sync.RWMutex
+	checkers map[string]FormatChecker
+}
+
+// FormatCheckers is the package-level registry consulted when validating a "format" keyword
+// against a JSON Schema.
+var FormatCheckers = &formatCheckerRegistry{checkers: make(map[string]FormatChecker)}
+
+// Add registers c under name, replacing any existing checker of the same name.
+func (r *formatCheckerRegistry) Add(name string, c FormatChecker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers[name] = c
+}
+
+// Remove unregisters the checker for name, if any.
+func (r *formatCheckerRegistry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checkers, name)
+}
+
+// Has returns whether a checker is registered under name.
+func (r *formatCheckerRegistry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.checkers[name]
+	return ok
+}
+
+// get returns the checker registered under name, if any.
+func (r *formatCheckerRegistry) get(name string) (FormatChecker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.checkers[name]
+	return c, ok
+}
+
+func init() {
+	FormatCheckers.Add("date-time", dateTimeFormatChecker{})
+	FormatCheckers.Add("duration", durationFormatChecker{})
+	FormatCheckers.Add("uuid", uuidFormatChecker{})
+	FormatCheckers.Add("email", emailFormatChecker{})
+	FormatCheckers.Add("ipv4", ipv4FormatChecker{})
+	FormatCheckers.Add("ipv6", ipv6FormatChecker{})
+	FormatCheckers.Add("uri", uriFormatChecker{})
+}
+
+type dateTimeFormatChecker struct{}
+
+func (dateTimeFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
+type durationFormatChecker struct{}
+
+func (durationFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type uuidFormatChecker struct{}
+
+func (uuidFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	return uuidPattern.MatchString(s)
+}
+
+type emailFormatChecker struct{}
+
+func (emailFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	_, err := mail.ParseAddress(s)
+	return err == nil
+}
+
+type ipv4FormatChecker struct{}
+
+func (ipv4FormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil
+}
+
+type ipv6FormatChecker struct{}
+
+func (ipv6FormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil
+}
+
+type uriFormatChecker struct{}
+
+func (uriFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	u, err := url.ParseRequestURI(s)
+	return err == nil && u.Scheme != ""
+}
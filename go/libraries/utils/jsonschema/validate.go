@@ -0,0 +1,196 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// JSONSchema is a JSON Schema loaded from disk, used to validate rows as they are read or
+// written. It supports the subset of the spec dolt import/export cares about: "type",
+// "required", "properties", "items", and "format" (dispatched through FormatCheckers).
+type JSONSchema struct {
+	node *schemaNode
+}
+
+type schemaNode struct {
+	Type       string                 `json:"type"`
+	Format     string                 `json:"format"`
+	Required   []string               `json:"required"`
+	Properties map[string]*schemaNode `json:"properties"`
+	Items      *schemaNode            `json:"items"`
+}
+
+// ValidationError describes a single JSON Schema violation, identified by a JSON pointer
+// (RFC 6901 path syntax) to the offending field. Property names are inserted as-is; a "~" or
+// "/" inside a name is not escaped.
+type ValidationError struct {
+	Pointer string
+	Message string
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", v.Pointer, v.Message)
+}
+
+// RowValidationError wraps every ValidationError found for a single row.
+type RowValidationError struct {
+	Errors []ValidationError
+}
+
+func (e *RowValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, verr := range e.Errors {
+		msgs[i] = verr.Error()
+	}
+	return "row failed schema validation: " + strings.Join(msgs, "; ")
+}
+
+// BadRowSink receives rows that fail schema validation instead of aborting the read or write.
+type BadRowSink interface {
+	WriteBadRow(rowVals map[string]interface{}, errs []ValidationError) error
+}
+
+// LoadJSONSchema reads and parses the JSON Schema document at path.
+func LoadJSONSchema(fs filesys.ReadableFS, path string) (*JSONSchema, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var node schemaNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	return &JSONSchema{node: &node}, nil
+}
+
+// ParseJSONSchema parses a JSON Schema document already held in memory, e.g. one embedded into
+// the binary with embed.FS.
+func ParseJSONSchema(data []byte) (*JSONSchema, error) {
+	var node schemaNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	return &JSONSchema{node: &node}, nil
+}
+
+// Validate checks rowVals against the schema, returning every violation found. A nil/empty
+// result means the row is valid.
+func (s *JSONSchema) Validate(rowVals map[string]interface{}) []ValidationError {
+	return s.node.validate("", rowVals)
+}
+
+func (n *schemaNode) validate(pointer string, val interface{}) []ValidationError {
+	var errs []ValidationError
+
+	if val == nil {
+		return errs
+	}
+
+	if n.Type != "" && !matchesType(n.Type, val) {
+		errs = append(errs, ValidationError{
+			Pointer: pointerOrRoot(pointer),
+			Message: fmt.Sprintf("expected type %q, got %T", n.Type, val),
+		})
+		return errs
+	}
+
+	if n.Format != "" {
+		if checker, ok := FormatCheckers.get(n.Format); ok && !checker.IsFormat(val) {
+			errs = append(errs, ValidationError{
+				Pointer: pointerOrRoot(pointer),
+				Message: fmt.Sprintf("value does not match format %q", n.Format),
+			})
+		}
+	}
+
+	if obj, ok := val.(map[string]interface{}); ok {
+		for _, req := range n.Required {
+			if _, ok := obj[req]; !ok {
+				errs = append(errs, ValidationError{
+					Pointer: pointer + "/" + req,
+					Message: "missing required property",
+				})
+			}
+		}
+
+		for name, child := range n.Properties {
+			if fieldVal, ok := obj[name]; ok {
+				errs = append(errs, child.validate(pointer+"/"+name, fieldVal)...)
+			}
+		}
+	}
+
+	if arr, ok := val.([]interface{}); ok && n.Items != nil {
+		for i, item := range arr {
+			errs = append(errs, n.Items.validate(fmt.Sprintf("%s/%d", pointer, i), item)...)
+		}
+	}
+
+	return errs
+}
+
+func pointerOrRoot(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
+
+func matchesType(typ string, val interface{}) bool {
+	switch typ {
+	case "string":
+		_, ok := val.(string)
+		return ok
+	case "boolean":
+		_, ok := val.(bool)
+		return ok
+	case "integer":
+		switch v := val.(type) {
+		case int:
+			return true
+		case int64:
+			return true
+		case float64:
+			return v == float64(int64(v))
+		default:
+			return false
+		}
+	case "number":
+		switch val.(type) {
+		case int, int64, float64:
+			return true
+		default:
+			return false
+		}
+	case "object":
+		_, ok := val.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := val.([]interface{})
+		return ok
+	case "null":
+		return val == nil
+	default:
+		return true
+	}
+}
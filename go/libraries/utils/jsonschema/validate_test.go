@@ -0,0 +1,48 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesTypeIntegerAcceptsNonFloatKinds(t *testing.T) {
+	// encoding/json always decodes numbers as float64, but callers that build rowVals from
+	// already-typed Go values (e.g. the JSON writer converting noms ints) pass native int/int64.
+	tests := []struct {
+		name string
+		typ  string
+		val  interface{}
+		want bool
+	}{
+		{name: "int", typ: "integer", val: int(3), want: true},
+		{name: "int64", typ: "integer", val: int64(3), want: true},
+		{name: "whole float64", typ: "integer", val: float64(3), want: true},
+		{name: "fractional float64", typ: "integer", val: float64(3.5), want: false},
+		{name: "string rejected", typ: "integer", val: "3", want: false},
+		{name: "number accepts int", typ: "number", val: int(3), want: true},
+		{name: "number accepts int64", typ: "number", val: int64(3), want: true},
+		{name: "number accepts float64", typ: "number", val: float64(3.5), want: true},
+		{name: "number rejects string", typ: "number", val: "3.5", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, matchesType(test.typ, test.val))
+		})
+	}
+}